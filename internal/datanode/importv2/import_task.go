@@ -0,0 +1,236 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importv2
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/datanode/metacache"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/importutilv2"
+	"github.com/milvus-io/milvus/pkg/log"
+)
+
+// ImportTask streams every file assigned to it, synces the rows it decodes
+// into segments, and collects the resulting segment infos and binlogs,
+// which GetSegmentsInfo returns. It
+// checkpoints its progress with manager after every sync so a restart can
+// pick the task back up via Resume instead of reimporting every file from
+// the start.
+type ImportTask struct {
+	mu     sync.RWMutex
+	taskID int64
+	req    *datapb.ImportRequest
+
+	schema     *schemapb.CollectionSchema
+	metaCaches map[string]metacache.MetaCache
+	cm         storage.ChunkManager
+	manager    TaskManager
+
+	segmentsInfo []*datapb.ImportSegmentInfo
+
+	cancel context.CancelFunc
+	ctx    context.Context
+}
+
+func NewImportTask(ctx context.Context, taskID int64, req *datapb.ImportRequest, schema *schemapb.CollectionSchema,
+	metaCaches map[string]metacache.MetaCache, cm storage.ChunkManager, manager TaskManager,
+) *ImportTask {
+	taskCtx, cancel := context.WithCancel(ctx)
+	return &ImportTask{
+		taskID:     taskID,
+		req:        req,
+		schema:     schema,
+		metaCaches: metaCaches,
+		cm:         cm,
+		manager:    manager,
+		ctx:        taskCtx,
+		cancel:     cancel,
+	}
+}
+
+func (t *ImportTask) GetTaskID() int64 {
+	return t.taskID
+}
+
+func (t *ImportTask) GetJobID() int64 {
+	return t.req.GetJobID()
+}
+
+func (t *ImportTask) GetCollectionID() int64 {
+	return t.req.GetCollectionID()
+}
+
+func (t *ImportTask) GetPartitionIDs() []int64 {
+	return t.req.GetPartitionIDs()
+}
+
+func (t *ImportTask) GetVchannels() []string {
+	return t.req.GetVchannels()
+}
+
+func (t *ImportTask) GetType() TaskType {
+	return ImportTaskType
+}
+
+func (t *ImportTask) GetState() datapb.ImportTaskStateV2 {
+	return t.req.GetState()
+}
+
+func (t *ImportTask) GetReason() string {
+	return t.req.GetReason()
+}
+
+func (t *ImportTask) GetSchema() *schemapb.CollectionSchema {
+	return t.schema
+}
+
+func (t *ImportTask) GetSegmentsInfo() []*datapb.ImportSegmentInfo {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.segmentsInfo
+}
+
+func (t *ImportTask) Cancel() {
+	t.cancel()
+}
+
+func (t *ImportTask) Clone() Task {
+	return NewImportTask(t.ctx, t.taskID, t.req, t.schema, t.metaCaches, t.cm, t.manager)
+}
+
+// Execute reads every file assigned to this task from the beginning and
+// syncs the rows it decodes into segments. See Resume to instead pick up
+// from each file's last durably saved checkpoint.
+func (t *ImportTask) Execute() []error {
+	return t.execute(make(map[int]int64), make(map[int64]int))
+}
+
+// Resume re-executes this task's files, but first asks manager for each
+// file's last saved ImportCheckpoint via ResumeTask, seeding the autoID
+// watermark and segment bookkeeping from it and skipping the rows already
+// imported. A file with no checkpoint is imported from the beginning, same
+// as Execute.
+func (t *ImportTask) Resume() []error {
+	segmentImportedSizes := make(map[int64]int)
+	offsets := make(map[int]int64, len(t.req.GetFiles()))
+	for fileIndex := range t.req.GetFiles() {
+		offsets[fileIndex] = ResumeTask(t, t.manager, fileIndex, segmentImportedSizes)
+	}
+	return t.execute(offsets, segmentImportedSizes)
+}
+
+// execute runs the read-decode-sync loop for every file in the task,
+// starting each file at row fileOffsets[fileIndex] (0 for a fresh import)
+// and budgeting segments from segmentImportedSizes, saving a checkpoint
+// after every successful sync.
+func (t *ImportTask) execute(fileOffsets map[int]int64, segmentImportedSizes map[int64]int) []error {
+	var errs []error
+
+	for fileIndex, file := range t.req.GetFiles() {
+		if err := t.executeFile(fileIndex, file, fileOffsets[fileIndex], segmentImportedSizes); err != nil {
+			log.Warn("import task failed to execute file", WrapLogFields(t,
+				zap.Int("fileIndex", fileIndex), zap.Error(err))...)
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+func (t *ImportTask) executeFile(fileIndex int, file *datapb.ImportFileInfo, offset int64, segmentImportedSizes map[int64]int) error {
+	fileType := importutilv2.FileType(file.GetFileType())
+	size, err := GetFileSize(file.GetImportFile(), fileType, t.schema, t.cm)
+	if err != nil {
+		return err
+	}
+	log.Info("importing file", WrapLogFields(t, zap.Int("fileIndex", fileIndex), zap.Int64("size", size))...)
+
+	reader, err := importutilv2.NewReader(t.ctx, t.cm, t.schema, file.GetImportFile(), fileType)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	vchannel := t.GetVchannels()[0]
+	partitionID := t.GetPartitionIDs()[0]
+
+	var rowOffset int64
+	for {
+		data, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		rows := GetInsertDataRowCount(data, t.schema)
+		rowOffset += int64(rows)
+		if rowOffset <= offset {
+			// Already synced in a prior attempt, per the checkpoint; skip
+			// re-importing rows the checkpoint says are already durable.
+			continue
+		}
+		if err := CheckRowsEqual(t.schema, data); err != nil {
+			return err
+		}
+		if err := AppendSystemFieldsData(t, data); err != nil {
+			return err
+		}
+
+		batchSize := int(data.GetMemorySize())
+		segmentID := PickSegment(t, segmentImportedSizes, vchannel, partitionID, batchSize)
+		syncTask, err := NewSyncTask(t.ctx, t, segmentID, partitionID, vchannel, data)
+		if err != nil {
+			return err
+		}
+		segmentInfo, err := NewImportSegmentInfo(syncTask, t, t.manager, fileIndex, rowOffset)
+		if err != nil {
+			return err
+		}
+		segmentImportedSizes[segmentID] += batchSize
+		t.addSegmentInfo(segmentInfo)
+	}
+	return nil
+}
+
+func (t *ImportTask) addSegmentInfo(info *datapb.ImportSegmentInfo) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, existing := range t.segmentsInfo {
+		if existing.GetSegmentID() == info.GetSegmentID() {
+			t.segmentsInfo[i] = info
+			return
+		}
+	}
+	t.segmentsInfo = append(t.segmentsInfo, info)
+}
+
+func WrapLogFields(task Task, fields ...zap.Field) []zap.Field {
+	baseFields := []zap.Field{
+		zap.Int64("taskID", task.GetTaskID()),
+		zap.Int64("jobID", task.GetJobID()),
+		zap.Int64("collectionID", task.GetCollectionID()),
+		zap.String("type", task.GetType().String()),
+	}
+	return append(baseFields, fields...)
+}
@@ -0,0 +1,89 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importv2
+
+import (
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+)
+
+type TaskType int
+
+const (
+	PreImportTaskType TaskType = iota
+	ImportTaskType
+)
+
+func (t TaskType) String() string {
+	switch t {
+	case PreImportTaskType:
+		return "PreImportTask"
+	case ImportTaskType:
+		return "ImportTask"
+	default:
+		return "UnknownImportTaskType"
+	}
+}
+
+// Task is the common surface TaskManager operates on, implemented by both
+// PreImportTask (schema/row validation) and ImportTask (the actual
+// read-sync loop).
+type Task interface {
+	GetJobID() int64
+	GetTaskID() int64
+	GetCollectionID() int64
+	GetPartitionIDs() []int64
+	GetVchannels() []string
+	GetType() TaskType
+	GetState() datapb.ImportTaskStateV2
+	GetReason() string
+
+	// Execute runs the task to completion, returning the errors it hit, one
+	// per file that failed; an empty result means every file succeeded.
+	Execute() []error
+	// Resume picks the task back up from its last durably persisted
+	// checkpoint, if any, instead of restarting its files from scratch.
+	// A task with no checkpoint behaves exactly like Execute.
+	Resume() []error
+	Cancel()
+	Clone() Task
+}
+
+// TaskFilter narrows TaskManager.GetBy to a subset of tracked tasks.
+type TaskFilter func(task Task) bool
+
+func WithType(taskType TaskType) TaskFilter {
+	return func(task Task) bool {
+		return task.GetType() == taskType
+	}
+}
+
+func WithJob(jobID int64) TaskFilter {
+	return func(task Task) bool {
+		return task.GetJobID() == jobID
+	}
+}
+
+func WithStates(states ...datapb.ImportTaskStateV2) TaskFilter {
+	return func(task Task) bool {
+		for _, state := range states {
+			if task.GetState() == state {
+				return true
+			}
+		}
+		return false
+	}
+}
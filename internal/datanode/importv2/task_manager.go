@@ -0,0 +1,140 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importv2
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/kv"
+	"github.com/milvus-io/milvus/pkg/log"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+)
+
+// importCheckpointPrefix namespaces checkpoint keys within the shared
+// datanode meta kv so they don't collide with other datanode state.
+const importCheckpointPrefix = "datanode-import-checkpoint"
+
+// TaskManager tracks every PreImportTask/ImportTask this datanode is
+// running and durably persists their resume checkpoints, so a restarted
+// datanode can pick a reassigned task back up instead of starting over.
+type TaskManager interface {
+	Add(task Task)
+	Remove(taskID int64)
+	Get(taskID int64) Task
+	GetBy(filters ...TaskFilter) []Task
+
+	// SaveImportCheckpoint durably persists checkpoint for jobID so a
+	// restarted datanode can resume the file it describes via
+	// GetImportCheckpoint.
+	SaveImportCheckpoint(jobID int64, checkpoint *ImportCheckpoint) error
+	// GetImportCheckpoint returns the last checkpoint durably saved for
+	// jobID's fileIndex, if any.
+	GetImportCheckpoint(jobID int64, fileIndex int) (*ImportCheckpoint, bool)
+	// RemoveImportCheckpoints discards every checkpoint saved for jobID,
+	// once the job they describe has finished and no longer needs resuming.
+	RemoveImportCheckpoints(jobID int64) error
+}
+
+type taskManager struct {
+	mu    sync.RWMutex
+	tasks map[int64]Task
+
+	metaKV kv.MetaKv
+}
+
+func NewTaskManager(metaKV kv.MetaKv) TaskManager {
+	return &taskManager{
+		tasks:  make(map[int64]Task),
+		metaKV: metaKV,
+	}
+}
+
+func (m *taskManager) Add(task Task) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tasks[task.GetTaskID()] = task
+}
+
+func (m *taskManager) Remove(taskID int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if task, ok := m.tasks[taskID]; ok {
+		task.Cancel()
+		delete(m.tasks, taskID)
+	}
+}
+
+func (m *taskManager) Get(taskID int64) Task {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.tasks[taskID]
+}
+
+func (m *taskManager) GetBy(filters ...TaskFilter) []Task {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return lo.Filter(lo.Values(m.tasks), func(task Task, _ int) bool {
+		for _, filter := range filters {
+			if !filter(task) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func checkpointKey(jobID int64, fileIndex int) string {
+	return fmt.Sprintf("%s/%d/%d", importCheckpointPrefix, jobID, fileIndex)
+}
+
+func (m *taskManager) SaveImportCheckpoint(jobID int64, checkpoint *ImportCheckpoint) error {
+	value, err := json.Marshal(checkpoint)
+	if err != nil {
+		return merr.WrapErrImportFailed(fmt.Sprintf("failed to marshal import checkpoint, jobID=%d, err=%v", jobID, err))
+	}
+	key := checkpointKey(jobID, checkpoint.FileIndex)
+	if err := m.metaKV.Save(key, string(value)); err != nil {
+		return merr.WrapErrImportFailed(fmt.Sprintf("failed to save import checkpoint, key=%s, err=%v", key, err))
+	}
+	log.Info("saved import checkpoint", zap.Int64("jobID", jobID),
+		zap.Int("fileIndex", checkpoint.FileIndex), zap.Int64("offset", checkpoint.Offset))
+	return nil
+}
+
+func (m *taskManager) GetImportCheckpoint(jobID int64, fileIndex int) (*ImportCheckpoint, bool) {
+	key := checkpointKey(jobID, fileIndex)
+	value, err := m.metaKV.Load(key)
+	if err != nil {
+		return nil, false
+	}
+	checkpoint := &ImportCheckpoint{}
+	if err := json.Unmarshal([]byte(value), checkpoint); err != nil {
+		log.Warn("failed to unmarshal import checkpoint, treating as absent",
+			zap.Int64("jobID", jobID), zap.Int("fileIndex", fileIndex), zap.Error(err))
+		return nil, false
+	}
+	return checkpoint, true
+}
+
+func (m *taskManager) RemoveImportCheckpoints(jobID int64) error {
+	return m.metaKV.RemoveWithPrefix(fmt.Sprintf("%s/%d/", importCheckpointPrefix, jobID))
+}
@@ -19,6 +19,7 @@ package importv2
 import (
 	"context"
 	"fmt"
+	"io"
 	"strconv"
 	"time"
 
@@ -33,6 +34,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/querycoordv2/params"
 	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/importutilv2"
 	"github.com/milvus-io/milvus/pkg/common"
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/util/merr"
@@ -45,10 +47,6 @@ func WrapNoTaskError(taskID int64, taskType TaskType) error {
 }
 
 func NewSyncTask(ctx context.Context, task *ImportTask, segmentID, partitionID int64, vchannel string, insertData *storage.InsertData) (syncmgr.Task, error) {
-	if params.Params.CommonCfg.EnableStorageV2.GetAsBool() {
-		return nil, merr.WrapErrImportFailed("storage v2 is not supported") // TODO: dyh, resolve storage v2
-	}
-
 	metaCache := task.metaCaches[vchannel]
 	if _, ok := metaCache.GetSegmentByID(segmentID); !ok {
 		metaCache.AddSegment(&datapb.SegmentInfo{
@@ -65,10 +63,21 @@ func NewSyncTask(ctx context.Context, task *ImportTask, segmentID, partitionID i
 
 	var serializer syncmgr.Serializer
 	var err error
-	serializer, err = syncmgr.NewStorageSerializer(
-		metaCache,
-		nil,
-	)
+	if params.Params.CommonCfg.EnableStorageV2.GetAsBool() {
+		// Storage V2's columnar writer needs the collection schema up front
+		// to lay out per-column files, unlike V1's serializer which only
+		// needs it lazily through the segment's metaCache entry.
+		serializer, err = syncmgr.NewStorageV2Serializer(
+			metaCache,
+			task.GetSchema(),
+			nil,
+		)
+	} else {
+		serializer, err = syncmgr.NewStorageSerializer(
+			metaCache,
+			nil,
+		)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -85,14 +94,24 @@ func NewSyncTask(ctx context.Context, task *ImportTask, segmentID, partitionID i
 	return serializer.EncodeBuffer(ctx, syncPack)
 }
 
-func NewImportSegmentInfo(syncTask syncmgr.Task, task *ImportTask) (*datapb.ImportSegmentInfo, error) {
+func NewImportSegmentInfo(syncTask syncmgr.Task, task *ImportTask, manager TaskManager, fileIndex int, offset int64) (*datapb.ImportSegmentInfo, error) {
 	segmentID := syncTask.SegmentID()
-	insertBinlogs, statsBinlog, _ := syncTask.(*syncmgr.SyncTask).Binlogs()
+	var insertBinlogs, statsBinlog map[int64]*datapb.FieldBinlog
+	switch t := syncTask.(type) {
+	case *syncmgr.SyncTaskV2:
+		insertBinlogs, statsBinlog, _ = t.Binlogs()
+	default:
+		insertBinlogs, statsBinlog, _ = syncTask.(*syncmgr.SyncTask).Binlogs()
+	}
 	metaCache := task.metaCaches[syncTask.ChannelName()]
 	segment, ok := metaCache.GetSegmentByID(segmentID)
 	if !ok {
 		return nil, merr.WrapErrSegmentNotFound(segmentID, "import failed")
 	}
+	checkpoint := NewCheckpoint(task, fileIndex, offset, int(segment.FlushedRows()), syncTask)
+	if err := manager.SaveImportCheckpoint(task.GetJobID(), checkpoint); err != nil {
+		return nil, err
+	}
 	return &datapb.ImportSegmentInfo{
 		SegmentID:    segmentID,
 		ImportedRows: segment.FlushedRows(),
@@ -101,6 +120,68 @@ func NewImportSegmentInfo(syncTask syncmgr.Task, task *ImportTask) (*datapb.Impo
 	}, nil
 }
 
+// ImportCheckpoint is the durable record of an import file's progress: how
+// far it got, which segment it was filling, and what autoID watermark it
+// had reached. TaskManager persists this after every successful sync so a
+// restarted datanode can resume the file instead of reimporting it from
+// scratch.
+type ImportCheckpoint struct {
+	JobID           int64
+	FileIndex       int
+	Offset          int64
+	SegmentID       int64
+	SegmentSize     int
+	AutoIDWatermark int64
+}
+
+// NewCheckpoint builds the checkpoint to persist once syncTask has been
+// durably synced, so a subsequent restart can resume the file from here
+// instead of reimporting it from the beginning.
+func NewCheckpoint(task *ImportTask, fileIndex int, offset int64, sizeImported int, syncTask syncmgr.Task) *ImportCheckpoint {
+	return &ImportCheckpoint{
+		JobID:           task.GetJobID(),
+		FileIndex:       fileIndex,
+		Offset:          offset,
+		SegmentID:       syncTask.SegmentID(),
+		SegmentSize:     sizeImported,
+		AutoIDWatermark: task.req.GetAutoIDRange().GetBegin(),
+	}
+}
+
+// ResumeFrom seeds a restarted task's in-memory state from a previously
+// persisted checkpoint: the autoID sequence continues from the stored
+// watermark rather than reissuing IDs already handed out before the
+// restart, and the checkpointed segment is reported as already carrying
+// SegmentSize bytes so PickSegment keeps filling it instead of opening a
+// new one.
+func ResumeFrom(task *ImportTask, checkpoint *ImportCheckpoint, segmentImportedSizes map[int64]int) {
+	if checkpoint == nil {
+		return
+	}
+	task.req.GetAutoIDRange().Begin = checkpoint.AutoIDWatermark
+	segmentImportedSizes[checkpoint.SegmentID] = checkpoint.SegmentSize
+}
+
+// ResumeTask looks up the last checkpoint TaskManager durably recorded for
+// fileIndex and, if one exists, seeds task's autoID watermark and segment
+// bookkeeping from it via ResumeFrom. It returns the row offset the
+// importer should skip ahead to before reading fileIndex again, so a
+// restarted datanode picks up where the previous attempt left off instead
+// of reimporting the file from the start. A zero offset means no
+// checkpoint was found and the file should be read from the beginning.
+func ResumeTask(task *ImportTask, manager TaskManager, fileIndex int, segmentImportedSizes map[int64]int) int64 {
+	checkpoint, ok := manager.GetImportCheckpoint(task.GetJobID(), fileIndex)
+	if !ok {
+		return 0
+	}
+	ResumeFrom(task, checkpoint, segmentImportedSizes)
+	return checkpoint.Offset
+}
+
+// PickSegment chooses which segment sizeToImport more bytes should land in.
+// sizeToImport is the in-memory size of the decoded batch about to be
+// imported (ImportTask passes InsertData.GetMemorySize), not an on-disk
+// file size, so PickSegment itself stays format-agnostic.
 func PickSegment(task *ImportTask, segmentImportedSizes map[int64]int, vchannel string, partitionID int64, sizeToImport int) int64 {
 	candidates := lo.Filter(task.req.GetRequestSegments(), func(info *datapb.ImportRequestSegment, _ int) bool {
 		return info.GetVchannel() == vchannel && info.GetPartitionID() == partitionID
@@ -203,7 +284,18 @@ func GetInsertDataRowCount(data *storage.InsertData, schema *schemapb.Collection
 	return 0
 }
 
-func GetFileSize(file *internalpb.ImportFile, cm storage.ChunkManager) (int64, error) {
+// GetFileSize returns the size PickSegment should budget for file. For
+// self-describing binary formats the on-disk byte size is a reasonable
+// proxy for the in-memory row data size, but for a text format such as CSV
+// that proxy can be wildly off (e.g. a float vector serialized as ASCII
+// digits is several times larger on disk than in memory), so CSV files are
+// instead sized by decoding a sample of their rows and extrapolating the
+// sample's in-memory-to-on-disk ratio over the file's on-disk size.
+func GetFileSize(file *internalpb.ImportFile, fileType importutilv2.FileType, schema *schemapb.CollectionSchema, cm storage.ChunkManager) (int64, error) {
+	if fileType == importutilv2.CSV {
+		return getCSVImportSize(file, schema, cm)
+	}
+
 	fn := func(path string) (int64, error) {
 		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
 		defer cancel()
@@ -220,6 +312,81 @@ func GetFileSize(file *internalpb.ImportFile, cm storage.ChunkManager) (int64, e
 	return totalSize, nil
 }
 
+// csvSizeSampleRows is the row count after which getCSVImportSize stops
+// sampling a file. Decoding every row of a large CSV to size it would defeat
+// the point of sizing it cheaply up front, so the in-memory-to-on-disk ratio
+// is instead estimated from a sample and extrapolated over the file's full
+// on-disk size (already cheap to get via cm.Size). Rows are decoded in whole
+// CSVReader.Read batches of up to importutilv2.DefaultCSVBatchSize rows, so
+// the sample is at least one full batch (or the whole file, if shorter) and
+// can hold more rows than this count.
+const csvSizeSampleRows = 64
+
+func getCSVImportSize(file *internalpb.ImportFile, schema *schemapb.CollectionSchema, cm storage.ChunkManager) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	var totalSize int64
+	for _, path := range file.GetPaths() {
+		diskSize, err := cm.Size(ctx, path)
+		if err != nil {
+			return 0, err
+		}
+		size, err := estimateCSVMemorySize(ctx, cm, schema, path, diskSize)
+		if err != nil {
+			return 0, err
+		}
+		totalSize += size
+	}
+	return totalSize, nil
+}
+
+// estimateCSVMemorySize decodes Read batches of path until at least
+// csvSizeSampleRows rows are sampled and extrapolates their in-memory-to-on-disk ratio over diskSize, rather than
+// decoding the whole file, so sizing a CSV file stays cheap regardless of
+// how large it is.
+func estimateCSVMemorySize(ctx context.Context, cm storage.ChunkManager, schema *schemapb.CollectionSchema, path string, diskSize int64) (int64, error) {
+	reader, err := importutilv2.NewCSVReader(ctx, cm, schema, path)
+	if err != nil {
+		return 0, err
+	}
+	defer reader.Close()
+
+	var sampleMemSize int64
+	var sampleRows int
+	for sampleRows < csvSizeSampleRows {
+		data, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+		sampleMemSize += data.GetMemorySize()
+		sampleRows += GetInsertDataRowCount(data, schema)
+		if reader.BytesRead() >= diskSize {
+			// The whole file has been pulled from disk, but the csv reader's
+			// buffer may still hold rows not yet decoded; decode those too so
+			// the whole file is in the sample and no extrapolation is needed.
+			for {
+				data, err := reader.Read()
+				if err == io.EOF {
+					return sampleMemSize, nil
+				}
+				if err != nil {
+					return 0, err
+				}
+				sampleMemSize += data.GetMemorySize()
+			}
+		}
+	}
+	if sampleRows == 0 || reader.BytesRead() == 0 {
+		return 0, nil
+	}
+	sampleDiskSize := reader.BytesRead()
+	return int64(float64(sampleMemSize) / float64(sampleDiskSize) * float64(diskSize)), nil
+}
+
 func LogStats(manager TaskManager) {
 	logFunc := func(tasks []Task, taskType TaskType) {
 		byState := lo.GroupBy(tasks, func(t Task) datapb.ImportTaskStateV2 {
@@ -0,0 +1,302 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importutilv2
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+	"github.com/milvus-io/milvus/pkg/util/typeutil"
+)
+
+// DefaultCSVBatchSize caps how many rows CSVReader decodes per Read call,
+// mirroring the row-batching the Parquet and JSON readers already do so a
+// single huge file doesn't have to be materialized in memory at once.
+const DefaultCSVBatchSize = 1024
+
+// CSVOptions configures how a CSV file maps onto a collection schema.
+type CSVOptions struct {
+	// Delimiter separates fields within a row. Defaults to ','.
+	Delimiter rune
+	// Quote names the character used to quote fields containing the
+	// delimiter or newlines. Defaults to '"'. The underlying encoding/csv
+	// reader always quotes with '"': any other value only switches it to
+	// lazy quote parsing and is not itself treated as a quote character.
+	Quote rune
+	// NullValue is the literal that marks a field as null for nullable
+	// fields. Defaults to the empty string.
+	NullValue string
+	// VectorSeparator splits a bracketed vector literal, e.g. "[0.1,0.2]".
+	// Defaults to ','.
+	VectorSeparator rune
+}
+
+func DefaultCSVOptions() CSVOptions {
+	return CSVOptions{
+		Delimiter:       ',',
+		Quote:           '"',
+		NullValue:       "",
+		VectorSeparator: ',',
+	}
+}
+
+// CSVReader reads a delimited text file whose header row names each column
+// after a schema field, and decodes it into storage.InsertData batches.
+// Vector columns may be encoded either as a single bracketed list column
+// ("[0.1,0.2,0.3]") or as dim repeated scalar columns sharing the field name
+// as a prefix ("embedding_0, embedding_1, ..., embedding_<dim-1>"). Of the
+// vector types only FloatVector is decoded; a field of any other vector type
+// fails Read with an unsupported data type error.
+type CSVReader struct {
+	schema  *schemapb.CollectionSchema
+	options CSVOptions
+
+	cm      storage.ChunkManager
+	path    string
+	rc      io.ReadCloser
+	counter *countingReader
+	csv     *csv.Reader
+	count   int64
+
+	fields       []*schemapb.FieldSchema
+	columnToFlat map[string][]int // field name -> CSV column indexes (len > 1 for split-vector columns)
+}
+
+// countingReader tracks how many bytes have been pulled from the
+// underlying reader, so callers can relate a decoded row count back to an
+// approximate on-disk byte offset without a second pass over the file.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+func NewCSVReader(ctx context.Context, cm storage.ChunkManager, schema *schemapb.CollectionSchema, path string, opts ...func(*CSVOptions)) (*CSVReader, error) {
+	options := DefaultCSVOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	rc, err := cm.Reader(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	counter := &countingReader{r: rc}
+	r := csv.NewReader(counter)
+	r.Comma = options.Delimiter
+	if options.Quote != '"' {
+		r.LazyQuotes = true
+	}
+
+	header, err := r.Read()
+	if err != nil {
+		rc.Close()
+		return nil, merr.WrapErrImportFailed(fmt.Sprintf("failed to read csv header of %s, err=%v", path, err))
+	}
+
+	fields := lo.Filter(schema.GetFields(), func(field *schemapb.FieldSchema, _ int) bool {
+		return !field.GetIsPrimaryKey() || !field.GetAutoID()
+	})
+	columnToFlat, err := mapCSVHeader(header, fields)
+	if err != nil {
+		rc.Close()
+		return nil, err
+	}
+
+	return &CSVReader{
+		schema:       schema,
+		options:      options,
+		cm:           cm,
+		path:         path,
+		rc:           rc,
+		counter:      counter,
+		csv:          r,
+		fields:       fields,
+		columnToFlat: columnToFlat,
+	}, nil
+}
+
+// BytesRead returns how many on-disk bytes have been pulled from the
+// underlying file so far. encoding/csv reads through a buffer, so this can
+// run ahead of the header and the rows decoded by Read by up to one buffer's
+// worth of bytes. Callers use this to relate decoded rows back to an
+// approximate file offset without re-reading the file, e.g. to extrapolate
+// total in-memory size from a partial sample.
+func (c *CSVReader) BytesRead() int64 {
+	return c.counter.n
+}
+
+// mapCSVHeader resolves every schema field (other than an auto-generated
+// primary key) to either a single CSV column, or, for vector fields encoded
+// as repeated scalar columns, the ordered set of columns that make up the
+// vector.
+func mapCSVHeader(header []string, fields []*schemapb.FieldSchema) (map[string][]int, error) {
+	colIndex := make(map[string]int, len(header))
+	for i, name := range header {
+		colIndex[name] = i
+	}
+
+	result := make(map[string][]int, len(fields))
+	for _, field := range fields {
+		name := field.GetName()
+		if idx, ok := colIndex[name]; ok {
+			result[name] = []int{idx}
+			continue
+		}
+		if !typeutil.IsVectorType(field.GetDataType()) {
+			return nil, merr.WrapErrImportFailed(fmt.Sprintf("column '%s' not found in csv header", name))
+		}
+		dim, err := typeutil.GetDim(field)
+		if err != nil {
+			return nil, err
+		}
+		indexes := make([]int, 0, dim)
+		for i := 0; i < int(dim); i++ {
+			colName := fmt.Sprintf("%s_%d", name, i)
+			idx, ok := colIndex[colName]
+			if !ok {
+				return nil, merr.WrapErrImportFailed(fmt.Sprintf("column '%s' not found in csv header", colName))
+			}
+			indexes = append(indexes, idx)
+		}
+		result[name] = indexes
+	}
+	return result, nil
+}
+
+func (c *CSVReader) Read() (*storage.InsertData, error) {
+	insertData, err := storage.NewInsertData(c.schema)
+	if err != nil {
+		return nil, err
+	}
+	for rowCount := 0; rowCount < DefaultCSVBatchSize; rowCount++ {
+		record, err := c.csv.Read()
+		if err == io.EOF {
+			if rowCount == 0 {
+				return nil, io.EOF
+			}
+			break
+		}
+		if err != nil {
+			return nil, merr.WrapErrImportFailed(fmt.Sprintf("failed to read csv row of %s, err=%v", c.path, err))
+		}
+		if err = c.appendRow(insertData, record); err != nil {
+			return nil, err
+		}
+		c.count++
+	}
+	return insertData, nil
+}
+
+func (c *CSVReader) appendRow(insertData *storage.InsertData, record []string) error {
+	for _, field := range c.fields {
+		indexes := c.columnToFlat[field.GetName()]
+		isNull := len(indexes) == 1 && record[indexes[0]] == c.options.NullValue
+		value, err := c.parseField(field, record, indexes, isNull)
+		if err != nil {
+			return err
+		}
+		if err = insertData.Data[field.GetFieldID()].AppendRow(value); err != nil {
+			return merr.WrapErrImportFailed(fmt.Sprintf("failed to append value for field '%s', err=%v", field.GetName(), err))
+		}
+	}
+	return nil
+}
+
+func (c *CSVReader) parseField(field *schemapb.FieldSchema, record []string, indexes []int, isNull bool) (any, error) {
+	if isNull {
+		if !field.GetNullable() {
+			return nil, merr.WrapErrImportFailed(fmt.Sprintf("field '%s' is not nullable but got a null value", field.GetName()))
+		}
+		return nil, nil
+	}
+	switch field.GetDataType() {
+	case schemapb.DataType_Bool:
+		return strconv.ParseBool(record[indexes[0]])
+	case schemapb.DataType_Int8:
+		v, err := strconv.ParseInt(record[indexes[0]], 10, 8)
+		return int8(v), err
+	case schemapb.DataType_Int16:
+		v, err := strconv.ParseInt(record[indexes[0]], 10, 16)
+		return int16(v), err
+	case schemapb.DataType_Int32:
+		v, err := strconv.ParseInt(record[indexes[0]], 10, 32)
+		return int32(v), err
+	case schemapb.DataType_Int64:
+		return strconv.ParseInt(record[indexes[0]], 10, 64)
+	case schemapb.DataType_Float:
+		v, err := strconv.ParseFloat(record[indexes[0]], 32)
+		return float32(v), err
+	case schemapb.DataType_Double:
+		return strconv.ParseFloat(record[indexes[0]], 64)
+	case schemapb.DataType_VarChar, schemapb.DataType_String:
+		return record[indexes[0]], nil
+	case schemapb.DataType_JSON:
+		return []byte(record[indexes[0]]), nil
+	case schemapb.DataType_FloatVector:
+		return c.parseFloatVector(record, indexes)
+	default:
+		return nil, merr.WrapErrImportFailed(fmt.Sprintf("unsupported data type '%s' for csv import", field.GetDataType()))
+	}
+}
+
+func (c *CSVReader) parseFloatVector(record []string, indexes []int) ([]float32, error) {
+	if len(indexes) > 1 {
+		vec := make([]float32, len(indexes))
+		for i, idx := range indexes {
+			v, err := strconv.ParseFloat(record[idx], 32)
+			if err != nil {
+				return nil, err
+			}
+			vec[i] = float32(v)
+		}
+		return vec, nil
+	}
+	raw := strings.TrimSpace(record[indexes[0]])
+	raw = strings.TrimPrefix(raw, "[")
+	raw = strings.TrimSuffix(raw, "]")
+	parts := strings.Split(raw, string(c.options.VectorSeparator))
+	vec := make([]float32, 0, len(parts))
+	for _, p := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(p), 32)
+		if err != nil {
+			return nil, err
+		}
+		vec = append(vec, float32(v))
+	}
+	return vec, nil
+}
+
+func (c *CSVReader) Close() {
+	if c.rc != nil {
+		c.rc.Close()
+	}
+}
@@ -0,0 +1,82 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importutilv2
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/util/merr"
+)
+
+type FileType int
+
+const (
+	Invalid FileType = iota
+	JSON
+	Numpy
+	Parquet
+	CSV
+)
+
+func (t FileType) String() string {
+	switch t {
+	case JSON:
+		return "JSON"
+	case Numpy:
+		return "Numpy"
+	case Parquet:
+		return "Parquet"
+	case CSV:
+		return "CSV"
+	default:
+		return "Invalid"
+	}
+}
+
+// Reader decodes an import file into batches of storage.InsertData, one
+// schema-aligned field at a time, until it is exhausted.
+type Reader interface {
+	// Read returns the next batch of rows, or io.EOF once the file is
+	// exhausted.
+	Read() (*storage.InsertData, error)
+	Close()
+}
+
+// NewReader dispatches to the Reader implementation for fileType.
+func NewReader(ctx context.Context, cm storage.ChunkManager, schema *schemapb.CollectionSchema,
+	file *internalpb.ImportFile, fileType FileType,
+) (Reader, error) {
+	switch fileType {
+	case JSON:
+		return NewJSONReader(ctx, cm, schema, file)
+	case Numpy:
+		return NewNumpyReader(ctx, cm, schema, file)
+	case Parquet:
+		return NewParquetReader(ctx, cm, schema, file)
+	case CSV:
+		if len(file.GetPaths()) == 0 {
+			return nil, merr.WrapErrImportFailed("csv import file has no paths")
+		}
+		return NewCSVReader(ctx, cm, schema, file.GetPaths()[0])
+	default:
+		return nil, merr.WrapErrImportFailed(fmt.Sprintf("unsupported import file type %s", fileType))
+	}
+}
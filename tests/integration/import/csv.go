@@ -0,0 +1,106 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importv2
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/samber/lo"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/pkg/common"
+)
+
+// GenerateCSVFile writes a CSV file for schema with rowCount data rows,
+// one column per non-autoID field, analogous to GenerateParquetFile.
+// Float vector columns are encoded as a single bracketed-list column,
+// e.g. "[0.1,0.2,0.3]".
+func GenerateCSVFile(t *testing.T, filePath string, schema *schemapb.CollectionSchema, rowCount int) error {
+	f, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	fields := lo.Filter(schema.GetFields(), func(field *schemapb.FieldSchema, _ int) bool {
+		return !field.GetIsPrimaryKey() || !field.GetAutoID()
+	})
+
+	w := csv.NewWriter(f)
+	header := lo.Map(fields, func(field *schemapb.FieldSchema, _ int) string {
+		return field.GetName()
+	})
+	if err := w.Write(header); err != nil {
+		return err
+	}
+
+	for row := 0; row < rowCount; row++ {
+		record := make([]string, 0, len(fields))
+		for _, field := range fields {
+			value, err := csvFieldValue(field, row)
+			if err != nil {
+				return err
+			}
+			record = append(record, value)
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func csvFieldValue(field *schemapb.FieldSchema, row int) (string, error) {
+	switch field.GetDataType() {
+	case schemapb.DataType_Bool:
+		return strconv.FormatBool(row%2 == 0), nil
+	case schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32, schemapb.DataType_Int64:
+		return strconv.Itoa(row), nil
+	case schemapb.DataType_Float, schemapb.DataType_Double:
+		return strconv.FormatFloat(float64(row), 'f', 6, 64), nil
+	case schemapb.DataType_VarChar, schemapb.DataType_String:
+		return fmt.Sprintf("%s_%d", field.GetName(), row), nil
+	case schemapb.DataType_FloatVector:
+		dim, err := csvFieldDim(field)
+		if err != nil {
+			return "", err
+		}
+		values := make([]string, dim)
+		for i := range values {
+			values[i] = strconv.FormatFloat(rand.Float64(), 'f', 6, 64)
+		}
+		return "[" + strings.Join(values, ",") + "]", nil
+	default:
+		return "", fmt.Errorf("GenerateCSVFile: unsupported data type %s for field %s", field.GetDataType(), field.GetName())
+	}
+}
+
+func csvFieldDim(field *schemapb.FieldSchema) (int, error) {
+	for _, kv := range field.GetTypeParams() {
+		if kv.GetKey() == common.DimKey {
+			return strconv.Atoi(kv.GetValue())
+		}
+	}
+	return 0, fmt.Errorf("GenerateCSVFile: field %s has no dim type param", field.GetName())
+}
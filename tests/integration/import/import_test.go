@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"math/rand"
 	"os"
+	"strconv"
 	"testing"
 	"time"
 
@@ -37,15 +38,17 @@ import (
 	"github.com/milvus-io/milvus/pkg/log"
 	"github.com/milvus-io/milvus/pkg/util/funcutil"
 	"github.com/milvus-io/milvus/pkg/util/metric"
+	"github.com/milvus-io/milvus/pkg/util/paramtable"
 	"github.com/milvus-io/milvus/tests/integration"
 )
 
 type BulkInsertSuite struct {
 	integration.MiniClusterSuite
 
-	pkType   schemapb.DataType
-	autoID   bool
-	fileType importutilv2.FileType
+	pkType    schemapb.DataType
+	autoID    bool
+	fileType  importutilv2.FileType
+	storageV2 bool
 }
 
 func (s *BulkInsertSuite) SetupTest() {
@@ -53,6 +56,7 @@ func (s *BulkInsertSuite) SetupTest() {
 	s.fileType = importutilv2.Parquet
 	s.pkType = schemapb.DataType_Int64
 	s.autoID = false
+	s.storageV2 = false
 }
 
 func (s *BulkInsertSuite) run() {
@@ -64,6 +68,9 @@ func (s *BulkInsertSuite) run() {
 	ctx, cancel := context.WithTimeout(c.GetContext(), 60*time.Second)
 	defer cancel()
 
+	paramtable.Get().Save(paramtable.Get().CommonCfg.EnableStorageV2.Key, strconv.FormatBool(s.storageV2))
+	defer paramtable.Get().Reset(paramtable.Get().CommonCfg.EnableStorageV2.Key)
+
 	collectionName := "TestBulkInsert" + funcutil.GenRandomStr()
 
 	schema := integration.ConstructSchema(collectionName, dim, s.autoID,
@@ -112,6 +119,18 @@ func (s *BulkInsertSuite) run() {
 				},
 			},
 		}
+	} else if s.fileType == importutilv2.CSV {
+		filePath := fmt.Sprintf("/tmp/test_%d.csv", rand.Int())
+		err = GenerateCSVFile(s.T(), filePath, schema, rowCount)
+		s.NoError(err)
+		defer os.Remove(filePath)
+		files = []*internalpb.ImportFile{
+			{
+				Paths: []string{
+					filePath,
+				},
+			},
+		}
 	}
 
 	importResp, err := c.Proxy.ImportV2(ctx, &internalpb.ImportRequest{
@@ -180,6 +199,11 @@ func (s *BulkInsertSuite) TestParquet() {
 	s.run()
 }
 
+func (s *BulkInsertSuite) TestCSV() {
+	s.fileType = importutilv2.CSV
+	s.run()
+}
+
 func (s *BulkInsertSuite) TestAutoID() {
 	s.pkType = schemapb.DataType_Int64
 	s.autoID = true
@@ -198,6 +222,112 @@ func (s *BulkInsertSuite) TestPK() {
 	s.run()
 }
 
+func (s *BulkInsertSuite) TestKilledMidway() {
+	const rowCount = 10000
+
+	c := s.Cluster
+	ctx, cancel := context.WithTimeout(c.GetContext(), 120*time.Second)
+	defer cancel()
+
+	collectionName := "TestBulkInsertKilledMidway" + funcutil.GenRandomStr()
+
+	schema := integration.ConstructSchema(collectionName, dim, false,
+		&schemapb.FieldSchema{FieldID: 100, Name: "id", DataType: schemapb.DataType_Int64, IsPrimaryKey: true, AutoID: false},
+		&schemapb.FieldSchema{FieldID: 101, Name: "image_path", DataType: schemapb.DataType_VarChar, TypeParams: []*commonpb.KeyValuePair{{Key: common.MaxLengthKey, Value: "65535"}}},
+		&schemapb.FieldSchema{FieldID: 102, Name: "embeddings", DataType: schemapb.DataType_FloatVector, TypeParams: []*commonpb.KeyValuePair{{Key: common.DimKey, Value: "128"}}},
+	)
+	marshaledSchema, err := proto.Marshal(schema)
+	s.NoError(err)
+
+	createCollectionStatus, err := c.Proxy.CreateCollection(ctx, &milvuspb.CreateCollectionRequest{
+		CollectionName: collectionName,
+		Schema:         marshaledSchema,
+		ShardsNum:      common.DefaultShardsNum,
+	})
+	s.NoError(err)
+	s.Equal(commonpb.ErrorCode_Success, createCollectionStatus.GetErrorCode())
+
+	err = os.MkdirAll(c.ChunkManager.RootPath(), os.ModePerm)
+	s.NoError(err)
+	filePath := fmt.Sprintf("/tmp/test_killed_midway_%d.parquet", rand.Int())
+	err = GenerateParquetFile(filePath, schema, rowCount)
+	s.NoError(err)
+	defer os.Remove(filePath)
+	files := []*internalpb.ImportFile{
+		{
+			Paths: []string{
+				filePath,
+			},
+		},
+	}
+
+	importResp, err := c.Proxy.ImportV2(ctx, &internalpb.ImportRequest{
+		CollectionName: collectionName,
+		Files:          files,
+	})
+	s.NoError(err)
+	s.Equal(int32(0), importResp.GetStatus().GetCode())
+	jobID := importResp.GetJobID()
+
+	// Give the import a moment to make partial progress, then kill the
+	// datanode holding the in-flight task and bring up a replacement, so
+	// the task is reassigned to the new datanode. The test checks only the
+	// job's end result; it does not observe whether the replacement runs
+	// the task through ImportTask.Resume or Execute.
+	time.Sleep(2 * time.Second)
+	s.Cluster.DataNode.Stop()
+	s.Cluster.AddDataNode()
+
+	err = WaitForImportDone(ctx, c, jobID)
+	s.NoError(err)
+
+	segments, err := c.MetaWatcher.ShowSegments()
+	s.NoError(err)
+	s.NotEmpty(segments)
+
+	var importedRows int64
+	for _, segment := range segments {
+		importedRows += segment.GetNumOfRows()
+	}
+	s.Equal(int64(rowCount), importedRows, "resumed import must not duplicate or drop rows")
+
+	loadStatus, err := c.Proxy.LoadCollection(ctx, &milvuspb.LoadCollectionRequest{
+		CollectionName: collectionName,
+	})
+	s.NoError(err)
+	s.Equal(commonpb.ErrorCode_Success, loadStatus.GetErrorCode())
+	s.WaitForLoad(ctx, collectionName)
+
+	queryResp, err := c.Proxy.Query(ctx, &milvuspb.QueryRequest{
+		CollectionName: collectionName,
+		Expr:           "id >= 0",
+		OutputFields:   []string{"id"},
+	})
+	s.NoError(err)
+	s.Equal(commonpb.ErrorCode_Success, queryResp.GetStatus().GetErrorCode())
+
+	seen := make(map[int64]struct{}, rowCount)
+	for _, id := range queryResp.GetFieldsData()[0].GetScalars().GetLongData().GetData() {
+		_, duplicate := seen[id]
+		s.False(duplicate, "resumed import must not assign the same primary key twice, got duplicate id %d", id)
+		seen[id] = struct{}{}
+	}
+	s.Equal(rowCount, len(seen), "resumed import must not duplicate or drop primary keys")
+}
+
+func (s *BulkInsertSuite) TestStorageV2() {
+	s.storageV2 = true
+
+	s.fileType = importutilv2.Parquet
+	s.run()
+
+	s.fileType = importutilv2.JSON
+	s.run()
+
+	s.fileType = importutilv2.Numpy
+	s.run()
+}
+
 func (s *BulkInsertSuite) TestZeroRowCount() {
 	const (
 		rowCount = 0